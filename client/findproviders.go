@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-delegated-routing/parser"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const mediaTypeNDJSON = "application/x-ndjson"
+
+// FindProvidersAsyncResult is a single result delivered while a
+// find-providers query is in flight.
+type FindProvidersAsyncResult struct {
+	AddrInfo []peer.AddrInfo
+	Err      error
+}
+
+// FindProvidersAsync issues a GET to endpoint for key, negotiates the
+// NDJSON response added by server.FindProvidersAsyncHandler, and streams
+// the decoded results into ch as they arrive. ch is closed once the
+// response body is exhausted or reading or decoding it fails (including
+// when ctx is done). If FindProvidersAsync returns an error, nothing is
+// sent on ch and ch is left open.
+//
+// endpoint is the full URL of the find-providers handler, matching
+// whatever path the caller mounted server.FindProvidersAsyncHandler at.
+func (fp *Client) FindProvidersAsync(ctx context.Context, httpClient *http.Client, endpoint string, key cid.Cid, ch chan<- FindProvidersAsyncResult) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	env := parser.Envelope{
+		Tag: parser.MethodGetP2PProvide,
+		Payload: &parser.GetP2PProvideRequest{
+			Key: parser.ToDJSpecialBytes(key.Hash()),
+		},
+	}
+	msg, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+url.QueryEscape(string(msg)), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", mediaTypeNDJSON)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return fmt.Errorf("unexpected status finding providers: %d", resp.StatusCode)
+	}
+
+	go func() {
+		defer resp.Body.Close()
+		DecodeFindProvidersNDJSON(resp.Body, ch)
+	}()
+	return nil
+}
+
+// DecodeFindProvidersNDJSON reads newline-delimited JSON envelopes from r,
+// as emitted by server.FindProvidersAsyncHandler's NDJSON response, and
+// delivers each decoded result on ch as it arrives. ch is closed once r is
+// exhausted or a decode error occurs.
+func DecodeFindProvidersNDJSON(r io.Reader, ch chan<- FindProvidersAsyncResult) {
+	defer close(ch)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		env := parser.Envelope{Payload: &parser.GetP2PProvideResponse{}}
+		if err := json.Unmarshal(line, &env); err != nil {
+			ch <- FindProvidersAsyncResult{Err: err}
+			return
+		}
+		resp, ok := env.Payload.(*parser.GetP2PProvideResponse)
+		if !ok {
+			continue
+		}
+		infos, err := ParseGetP2PProvideResponse(resp)
+		if err != nil {
+			ch <- FindProvidersAsyncResult{Err: err}
+			return
+		}
+		ch <- FindProvidersAsyncResult{AddrInfo: infos}
+	}
+	if err := scanner.Err(); err != nil {
+		ch <- FindProvidersAsyncResult{Err: err}
+	}
+}
+
+// ParseGetP2PProvideResponse is the client-side counterpart of
+// server.GenerateGetP2PProvideResponse.
+func ParseGetP2PProvideResponse(resp *parser.GetP2PProvideResponse) ([]peer.AddrInfo, error) {
+	infos := make([]peer.AddrInfo, 0, len(resp.Peers))
+	for _, raw := range resp.Peers {
+		addrBytes, err := parser.FromDJSpecialBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := multiaddr.NewMultiaddrBytes(addrBytes)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, peer.AddrInfo{Addrs: []multiaddr.Multiaddr{addr}})
+	}
+	return infos, nil
+}
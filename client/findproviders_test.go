@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ipfs/go-delegated-routing/parser"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// TestDecodeFindProvidersNDJSONRoundTrip checks that DecodeFindProvidersNDJSON
+// reconstructs the results server.writeFindProvidersNDJSON encodes, one line
+// per peer.AddrInfo-with-a-single-address as produced by
+// server.GenerateGetP2PProvideResponse.
+func TestDecodeFindProvidersNDJSONRoundTrip(t *testing.T) {
+	addr1, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/4001")
+	if err != nil {
+		t.Fatalf("building test multiaddr: %v", err)
+	}
+	addr2, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/4002")
+	if err != nil {
+		t.Fatalf("building test multiaddr: %v", err)
+	}
+
+	var buf bytes.Buffer
+	for _, addr := range []multiaddr.Multiaddr{addr1, addr2} {
+		env := parser.Envelope{
+			Tag: parser.MethodGetP2PProvide,
+			Payload: &parser.GetP2PProvideResponse{
+				Peers: []parser.DJSpecialBytes{parser.ToDJSpecialBytes(addr.Bytes())},
+			},
+		}
+		line, err := json.Marshal(env)
+		if err != nil {
+			t.Fatalf("marshaling envelope: %v", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	ch := make(chan FindProvidersAsyncResult)
+	go DecodeFindProvidersNDJSON(&buf, ch)
+
+	var got []peer.AddrInfo
+	for r := range ch {
+		if r.Err != nil {
+			t.Fatalf("unexpected decode error: %v", r.Err)
+		}
+		got = append(got, r.AddrInfo...)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 decoded addr infos, got %d", len(got))
+	}
+	if got[0].Addrs[0].String() != addr1.String() {
+		t.Errorf("first addr = %s, want %s", got[0].Addrs[0], addr1)
+	}
+	if got[1].Addrs[0].String() != addr2.String() {
+		t.Errorf("second addr = %s, want %s", got[1].Addrs[0], addr2)
+	}
+}
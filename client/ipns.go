@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ipfs/boxo/ipns"
+	delegatedrouting "github.com/ipfs/go-delegated-routing"
+)
+
+// GetIPNS fetches the IPNS record for name from the delegated-routing
+// endpoint at baseURL. httpClient may be nil, in which case
+// http.DefaultClient is used.
+func (fp *Client) GetIPNS(ctx context.Context, httpClient *http.Client, baseURL string, name string) (*ipns.Record, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+delegatedrouting.IPNSPath(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", delegatedrouting.MediaTypeIPNSRecord)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status fetching ipns record: %d", resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipns.UnmarshalRecord(raw)
+}
+
+// PutIPNS publishes rec as the IPNS record for name to the
+// delegated-routing endpoint at baseURL. httpClient may be nil, in which
+// case http.DefaultClient is used.
+func (fp *Client) PutIPNS(ctx context.Context, httpClient *http.Client, baseURL string, name string, rec *ipns.Record) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	raw, err := ipns.MarshalRecord(rec)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, baseURL+delegatedrouting.IPNSPath(name), bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", delegatedrouting.MediaTypeIPNSRecord)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status publishing ipns record: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -3,11 +3,11 @@ package client
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"errors"
 	"time"
 
 	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-delegated-routing/envelope"
 	"github.com/ipfs/go-delegated-routing/gen/proto"
 	"github.com/ipld/edelweiss/values"
 	"github.com/ipld/go-ipld-prime/codec/dagjson"
@@ -95,6 +95,11 @@ func parseProtocol(tp *proto.TransferProtocol) TransferProtocol {
 	return TransferProtocol{}
 }
 
+const (
+	signingDomainProvideRequest   = "libp2p-routing-record"
+	signingTypeHintProvideRequest = "/routing/v1/provide"
+)
+
 // ProvideRequest is a message indicating a provider can provide a Key for a given TTL
 type ProvideRequest struct {
 	Key cid.Cid
@@ -133,8 +138,7 @@ func (pr *ProvideRequest) Sign(key crypto.PrivKey) error {
 	if err = dagjson.Encode(nodeRepr, outBuf); err != nil {
 		return err
 	}
-	hash := sha256.New().Sum(outBuf.Bytes())
-	sig, err := key.Sign(hash)
+	sig, err := envelope.Sign(key, signingDomainProvideRequest, signingTypeHintProvideRequest, outBuf.Bytes())
 	if err != nil {
 		return err
 	}
@@ -158,14 +162,12 @@ func (pr *ProvideRequest) Verify() error {
 	if err := dagjson.Encode(nodeRepr, outBuf); err != nil {
 		return err
 	}
-	hash := sha256.New().Sum(outBuf.Bytes())
-
 	pk, err := pr.Peer.ID.ExtractPublicKey()
 	if err != nil {
 		return err
 	}
 
-	ok, err := pk.Verify(hash, sig)
+	ok, err := envelope.VerifyWithLegacyFallback(pk, signingDomainProvideRequest, signingTypeHintProvideRequest, outBuf.Bytes(), sig)
 	if err != nil {
 		return err
 	}
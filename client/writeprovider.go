@@ -0,0 +1,53 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	delegatedrouting "github.com/ipfs/go-delegated-routing"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ProvidePeerRecord signs req as peerID and PUTs it to
+// /routing/v1/providers at baseURL, announcing req.Provider for req.Keys.
+// httpClient may be nil, in which case http.DefaultClient is used.
+//
+// Unlike Provide, which speaks the legacy bindnode/DAG-JSON RPC
+// wire format, this sends the protocol-agnostic WriteProviderRequest that
+// server.ContentRouter.Provide expects.
+func (fp *Client) ProvidePeerRecord(ctx context.Context, httpClient *http.Client, baseURL string, peerID peer.ID, key crypto.PrivKey, req *delegatedrouting.WriteProviderRequest) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if err := req.Sign(peerID, key); err != nil {
+		return err
+	}
+
+	enc, err := req.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	body := append(append([]byte("["), enc...), ']')
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, baseURL+"/routing/v1/providers", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status providing peer record: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Package envelope implements domain-separated signing for delegated
+// routing requests. Without domain separation, a signature computed over
+// one request's payload can be replayed as a signature over any other
+// request whose payload happens to match those bytes; binding the digest to
+// a domain and a per-request-type hint rules that out.
+package envelope
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// Digest computes the domain-separated SHA-256 digest signed by Sign and
+// checked by Verify:
+//
+//	len(domain) || domain || len(typeHint) || typeHint || payload
+//
+// domain should be shared by all request types that are signed by the same
+// kind of key (e.g. "libp2p-routing-record"); typeHint should be unique per
+// request type (e.g. "/routing/v1/provide/bitswap") so that a signature
+// over one request type cannot be replayed as another.
+func Digest(domain, typeHint string, payload []byte) [32]byte {
+	h := sha256.New()
+	writeLenPrefixed(h, []byte(domain))
+	writeLenPrefixed(h, []byte(typeHint))
+	h.Write(payload)
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// Sign signs payload for domain and typeHint with key.
+func Sign(key crypto.PrivKey, domain, typeHint string, payload []byte) ([]byte, error) {
+	digest := Digest(domain, typeHint, payload)
+	return key.Sign(digest[:])
+}
+
+// Verify reports whether sig is a valid signature of payload for domain and
+// typeHint under pk.
+func Verify(pk crypto.PubKey, domain, typeHint string, payload, sig []byte) (bool, error) {
+	digest := Digest(domain, typeHint, payload)
+	return pk.Verify(digest[:], sig)
+}
+
+// VerifyWithLegacyFallback is Verify, but if sig does not check out against
+// the domain-separated digest it also tries the pre-envelope scheme that
+// request types in this module signed with before domain separation was
+// introduced: a signature over sha256.New().Sum(payload) (which appends the
+// SHA-256 digest of empty input to payload rather than hashing payload, but
+// is what earlier signers actually produced). This lets already-issued signatures keep
+// verifying while signers migrate; drop the fallback once they all have.
+func VerifyWithLegacyFallback(pk crypto.PubKey, domain, typeHint string, payload, sig []byte) (bool, error) {
+	ok, err := Verify(pk, domain, typeHint, payload, sig)
+	if err != nil || ok {
+		return ok, err
+	}
+	legacyHash := sha256.New().Sum(payload)
+	return pk.Verify(legacyHash, sig)
+}
+
+func writeLenPrefixed(h hash.Hash, b []byte) {
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(b)))
+	h.Write(lenBuf[:])
+	h.Write(b)
+}
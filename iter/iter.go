@@ -0,0 +1,44 @@
+// Package iter provides a small pull-based iterator used to stream results
+// out of a ContentRouter without requiring the whole result set to be
+// materialized up front.
+package iter
+
+// ResultIter is a pull-based iterator over results of type T. Callers call
+// Next until it returns false, reading the current value with Val in
+// between, then check Err to distinguish a clean end of iteration from one
+// that stopped early because of an error. Close must be called once the
+// caller is done, whether or not iteration ran to completion.
+type ResultIter[T any] interface {
+	Next() bool
+	Val() T
+	Err() error
+	Close() error
+}
+
+// FromSlice adapts a pre-computed slice to ResultIter, for ContentRouter
+// implementations that already have their full result set in memory.
+func FromSlice[T any](vals []T) ResultIter[T] {
+	return &sliceIter[T]{vals: vals, pos: -1}
+}
+
+type sliceIter[T any] struct {
+	vals []T
+	pos  int
+}
+
+func (it *sliceIter[T]) Next() bool {
+	it.pos++
+	return it.pos < len(it.vals)
+}
+
+func (it *sliceIter[T]) Val() T {
+	return it.vals[it.pos]
+}
+
+func (it *sliceIter[T]) Err() error {
+	return nil
+}
+
+func (it *sliceIter[T]) Close() error {
+	return nil
+}
@@ -12,6 +12,11 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 )
 
+const (
+	mediaTypeNDJSON = "application/x-ndjson"
+	mediaTypeJSON   = "application/json"
+)
+
 type FindProvidersAsyncFunc func(cid.Cid, chan<- client.FindProvidersAsyncResult) error
 
 func FindProvidersAsyncHandler(f FindProvidersAsyncFunc) http.HandlerFunc {
@@ -47,20 +52,10 @@ func FindProvidersAsyncHandler(f FindProvidersAsyncFunc) http.HandlerFunc {
 				writer.WriteHeader(500)
 				return
 			}
-			for x := range ch {
-				if x.Err != nil {
-					continue
-				}
-				resp := GenerateGetP2PProvideResponse(x.AddrInfo)
-				env := &parser.Envelope{
-					Tag:     parser.MethodGetP2PProvide,
-					Payload: resp,
-				}
-				enc, err := json.Marshal(env)
-				if err != nil {
-					continue
-				}
-				writer.Write(enc)
+			if request.Header.Get("Accept") == mediaTypeJSON {
+				writeFindProvidersJSONArray(writer, ch)
+			} else {
+				writeFindProvidersNDJSON(writer, ch)
 			}
 		default:
 			writer.WriteHeader(404)
@@ -68,6 +63,55 @@ func FindProvidersAsyncHandler(f FindProvidersAsyncFunc) http.HandlerFunc {
 	}
 }
 
+// writeFindProvidersNDJSON streams one JSON envelope per line as results
+// arrive on ch, flushing after each one so long-running queries can be
+// consumed (and cancelled) incrementally instead of buffered in full.
+func writeFindProvidersNDJSON(writer http.ResponseWriter, ch <-chan client.FindProvidersAsyncResult) {
+	writer.Header().Set("Content-Type", mediaTypeNDJSON)
+	flusher, _ := writer.(http.Flusher)
+	for x := range ch {
+		if x.Err != nil {
+			continue
+		}
+		env := &parser.Envelope{
+			Tag:     parser.MethodGetP2PProvide,
+			Payload: GenerateGetP2PProvideResponse(x.AddrInfo),
+		}
+		enc, err := json.Marshal(env)
+		if err != nil {
+			continue
+		}
+		writer.Write(enc)
+		writer.Write([]byte("\n"))
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// writeFindProvidersJSONArray buffers all results from ch and writes them
+// as a single JSON array, for legacy consumers that negotiated
+// Accept: application/json instead of NDJSON.
+func writeFindProvidersJSONArray(writer http.ResponseWriter, ch <-chan client.FindProvidersAsyncResult) {
+	writer.Header().Set("Content-Type", mediaTypeJSON)
+	envs := make([]*parser.Envelope, 0)
+	for x := range ch {
+		if x.Err != nil {
+			continue
+		}
+		envs = append(envs, &parser.Envelope{
+			Tag:     parser.MethodGetP2PProvide,
+			Payload: GenerateGetP2PProvideResponse(x.AddrInfo),
+		})
+	}
+	enc, err := json.Marshal(envs)
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	writer.Write(enc)
+}
+
 func ParseGetP2PProvideRequest(req *parser.GetP2PProvideRequest) (cid.Cid, error) {
 	mhBytes, err := parser.FromDJSpecialBytes(req.Key)
 	if err != nil {
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ipfs/boxo/ipns"
+	delegatedrouting "github.com/ipfs/go-delegated-routing"
+)
+
+// IPNSRouter is implemented by anything that can resolve and accept IPNS
+// records on behalf of IPNSHandler: a DHT, a database, an in-memory cache,
+// or a router that fans out to several of these.
+type IPNSRouter interface {
+	GetIPNS(ctx context.Context, name string) (*ipns.Record, error)
+	PutIPNS(ctx context.Context, name string, rec *ipns.Record) error
+}
+
+// IPNSHandler mounts GET/PUT /routing/v1/ipns/{name}, exchanging raw IPNS
+// record bytes with router under the delegated-routing IPNS record content
+// type.
+func IPNSHandler(router IPNSRouter) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		name := strings.TrimPrefix(request.URL.Path, delegatedrouting.IPNSPath(""))
+		if name == "" {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch request.Method {
+		case http.MethodGet:
+			getIPNS(writer, request, name, router)
+		case http.MethodPut:
+			putIPNS(writer, request, name, router)
+		default:
+			writer.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func getIPNS(writer http.ResponseWriter, request *http.Request, name string, router IPNSRouter) {
+	rec, err := router.GetIPNS(request.Context(), name)
+	if err != nil {
+		if errors.Is(err, ErrNotImplemented) || errors.Is(err, ErrTooManyRequests) {
+			writeRouterError(writer, err)
+			return
+		}
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	raw, err := ipns.MarshalRecord(rec)
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", delegatedrouting.MediaTypeIPNSRecord)
+	writer.Write(raw)
+}
+
+func putIPNS(writer http.ResponseWriter, request *http.Request, name string, router IPNSRouter) {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rec, err := ipns.UnmarshalRecord(body)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := router.PutIPNS(request.Context(), name, rec); err != nil {
+		writeRouterError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+}
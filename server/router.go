@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	delegatedrouting "github.com/ipfs/go-delegated-routing"
+	"github.com/ipfs/go-delegated-routing/iter"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// ErrNotImplemented is returned by a ContentRouter method the implementer
+// has chosen not to support; Handler translates it into a 501.
+var ErrNotImplemented = errors.New("not implemented")
+
+// ErrTooManyRequests is returned by a ContentRouter method that is
+// rate-limiting the caller; Handler translates it into a 429.
+var ErrTooManyRequests = errors.New("too many requests")
+
+const (
+	minProvideTTL = time.Minute
+	maxProvideTTL = 48 * time.Hour
+)
+
+// AnnouncementRecord is a single signed provider announcement accepted by
+// ContentRouter.Provide.
+type AnnouncementRecord struct {
+	delegatedrouting.WriteProviderRequest
+}
+
+// ContentRouter is implemented by anything that can answer delegated
+// routing v1 requests. Handler mounts every /routing/v1/* route on top of
+// a single ContentRouter, so middleware (metrics, auth, rate limiting) only
+// has to wrap one http.Handler instead of one per route.
+type ContentRouter interface {
+	FindProviders(ctx context.Context, key cid.Cid) (iter.ResultIter[delegatedrouting.PeerRecord], error)
+	Provide(ctx context.Context, reqs []AnnouncementRecord) ([]time.Duration, error)
+	FindPeer(ctx context.Context, id peer.ID) (iter.ResultIter[*delegatedrouting.PeerRecord], error)
+	IPNSRouter
+}
+
+// Handler mounts GET /routing/v1/providers/{cid}, PUT /routing/v1/providers,
+// GET /routing/v1/peers/{peer-id}, and GET/PUT /routing/v1/ipns/{name} on
+// top of router.
+func Handler(router ContentRouter) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/routing/v1/providers/", findProvidersHandler(router))
+	mux.HandleFunc("/routing/v1/providers", provideHandler(router))
+	mux.HandleFunc("/routing/v1/peers/", findPeerHandler(router))
+	mux.HandleFunc("/routing/v1/ipns/", IPNSHandler(router))
+	return mux
+}
+
+func writeRouterError(writer http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotImplemented):
+		writer.WriteHeader(http.StatusNotImplemented)
+	case errors.Is(err, ErrTooManyRequests):
+		writer.WriteHeader(http.StatusTooManyRequests)
+	default:
+		writer.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func findProvidersHandler(router ContentRouter) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet {
+			writer.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := strings.TrimPrefix(request.URL.Path, "/routing/v1/providers/")
+		c, err := cid.Decode(key)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		records, err := router.FindProviders(request.Context(), c)
+		if err != nil {
+			writeRouterError(writer, err)
+			return
+		}
+		defer records.Close()
+
+		writer.Header().Set("Content-Type", mediaTypeNDJSON)
+		flusher, _ := writer.(http.Flusher)
+		for records.Next() {
+			enc, err := json.Marshal(records.Val())
+			if err != nil {
+				continue
+			}
+			writer.Write(enc)
+			writer.Write([]byte("\n"))
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err := records.Err(); err != nil {
+			// The response has already started streaming, so a status code
+			// is no longer an option; the best we can do is log the
+			// failure and end the stream early.
+			log.Printf("find providers: iterating results: %v", err)
+		}
+	}
+}
+
+func findPeerHandler(router ContentRouter) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet {
+			writer.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		idStr := strings.TrimPrefix(request.URL.Path, "/routing/v1/peers/")
+		id, err := peer.Decode(idStr)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		records, err := router.FindPeer(request.Context(), id)
+		if err != nil {
+			writeRouterError(writer, err)
+			return
+		}
+		defer records.Close()
+
+		writer.Header().Set("Content-Type", mediaTypeNDJSON)
+		flusher, _ := writer.(http.Flusher)
+		for records.Next() {
+			enc, err := json.Marshal(records.Val())
+			if err != nil {
+				continue
+			}
+			writer.Write(enc)
+			writer.Write([]byte("\n"))
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err := records.Err(); err != nil {
+			// The response has already started streaming, so a status code
+			// is no longer an option; the best we can do is log the
+			// failure and end the stream early.
+			log.Printf("find peer: iterating results: %v", err)
+		}
+	}
+}
+
+func provideHandler(router ContentRouter) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPut {
+			writer.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var reqs []AnnouncementRecord
+		if err := json.NewDecoder(request.Body).Decode(&reqs); err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		for _, rec := range reqs {
+			if err := rec.Verify(); err != nil {
+				writer.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			ttl := time.Duration(rec.AdvisoryTTL)
+			if ttl < minProvideTTL || ttl > maxProvideTTL {
+				writer.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		ttls, err := router.Provide(request.Context(), reqs)
+		if err != nil {
+			writeRouterError(writer, err)
+			return
+		}
+
+		enc, err := json.Marshal(ttls)
+		if err != nil {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writer.Write(enc)
+	}
+}
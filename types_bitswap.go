@@ -1,18 +1,23 @@
 package delegatedrouting
 
 import (
-	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ipfs/go-delegated-routing/envelope"
 	"github.com/ipfs/go-delegated-routing/internal/drjson"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multibase"
 )
 
+const (
+	signingDomainWriteProviderRequest      = "libp2p-routing-record"
+	signingTypeHintBitswapWriteProviderReq = "/routing/v1/provide/bitswap"
+)
+
 type BitswapReadProviderResponse struct {
 	Protocol string
 	ID       *peer.ID
@@ -114,8 +119,7 @@ func (p *BitswapWriteProviderRequest) Sign(peerID peer.ID, key crypto.PrivKey) e
 	if err != nil {
 		return err
 	}
-	hash := sha256.New().Sum([]byte(p.rawPayload))
-	sig, err := key.Sign(hash)
+	sig, err := envelope.Sign(key, signingDomainWriteProviderRequest, signingTypeHintBitswapWriteProviderReq, []byte(p.rawPayload))
 	if err != nil {
 		return err
 	}
@@ -157,9 +161,7 @@ func (p *BitswapWriteProviderRequest) Verify() error {
 		return fmt.Errorf("multibase-decoding signature to verify: %w", err)
 	}
 
-	hash := sha256.New().Sum([]byte(p.rawPayload))
-
-	ok, err := pk.Verify(hash, sigBytes)
+	ok, err := envelope.VerifyWithLegacyFallback(pk, signingDomainWriteProviderRequest, signingTypeHintBitswapWriteProviderReq, []byte(p.rawPayload), sigBytes)
 	if err != nil {
 		return fmt.Errorf("verifying hash with signature: %w", err)
 	}
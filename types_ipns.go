@@ -0,0 +1,13 @@
+package delegatedrouting
+
+// MediaTypeIPNSRecord is the Content-Type used by the delegated IPNS
+// endpoints to carry a raw, signed IPNS record (as opposed to the
+// DAG-JSON envelopes used by the rest of this package).
+const MediaTypeIPNSRecord = "application/vnd.ipfs.ipns-record"
+
+// IPNSPath is the path, under a delegated-routing base URL, of the GET/PUT
+// IPNS record endpoint for name. name is the multibase-encoded routing key
+// for the IPNS name, as required by the delegated-routing IPNS spec.
+func IPNSPath(name string) string {
+	return "/routing/v1/ipns/" + name
+}
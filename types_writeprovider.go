@@ -0,0 +1,183 @@
+package delegatedrouting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-delegated-routing/envelope"
+	"github.com/ipfs/go-delegated-routing/internal/drjson"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multibase"
+)
+
+const signingTypeHintWriteProviderRequest = "/routing/v1/provide/peer"
+
+// PeerRecord is the protocol-agnostic description of a provider: a libp2p
+// identity, its multiaddrs, and the transfer protocols it supports.
+// Protocol-specific metadata for each entry in Protocols is carried as
+// opaque JSON in Protocol, keyed by the protocol name, so a router does not
+// need to understand a protocol in order to store and relay it.
+type PeerRecord struct {
+	ID        *peer.ID
+	Addrs     []Multiaddr
+	Protocols []string
+	Protocol  map[string]json.RawMessage
+}
+
+// WriteProviderRequest announces that a PeerRecord can provide Keys until
+// AdvisoryTTL elapses. Unlike BitswapWriteProviderRequest, it is not tied to
+// a single transfer protocol: Bitswap, GraphSync/Filecoin, HTTP-gateway, and
+// future transfer protocols can all be announced through the same
+// PeerRecord, so a new protocol does not require a new request type.
+type WriteProviderRequest struct {
+	WriteProviderRequestPayload
+	Protocol  string
+	Signature string
+
+	rawPayload string
+}
+
+type WriteProviderRequestPayload struct {
+	Keys        []CID
+	Timestamp   Time
+	AdvisoryTTL Duration
+	Provider    PeerRecord
+}
+
+func (p *WriteProviderRequest) GetPayload() WriteProviderRequestPayload {
+	return WriteProviderRequestPayload{}
+}
+
+func (p *WriteProviderRequest) MarshalJSON() ([]byte, error) {
+	wp := struct {
+		Protocol  string
+		Signature string
+		Payload   string
+	}{
+		Protocol: p.Protocol,
+	}
+
+	wp.Signature = p.Signature
+	wp.Payload = p.rawPayload
+
+	return drjson.MarshalJSONBytes(wp)
+}
+
+func (p *WriteProviderRequest) UnmarshalJSON(b []byte) error {
+	wp := struct {
+		Protocol  string
+		Signature string
+		Payload   string
+	}{}
+	err := json.Unmarshal(b, &wp)
+	if err != nil {
+		return err
+	}
+
+	p.Protocol = wp.Protocol
+	p.Signature = wp.Signature
+	p.rawPayload = wp.Payload
+
+	payload := WriteProviderRequestPayload{}
+	err = json.Unmarshal([]byte(p.rawPayload), &payload)
+	if err != nil {
+		return fmt.Errorf("unmarshaling payload: %w", err)
+	}
+
+	p.WriteProviderRequestPayload = payload
+
+	return nil
+}
+
+func (p *WriteProviderRequest) IsSigned() bool {
+	return p.Signature != ""
+}
+
+func (p *WriteProviderRequest) setRawPayload() error {
+	payloadBytes, err := drjson.MarshalJSONBytes(p.WriteProviderRequestPayload)
+	if err != nil {
+		return fmt.Errorf("marshaling write provider payload: %w", err)
+	}
+	p.rawPayload = string(payloadBytes)
+	return nil
+}
+
+func (p *WriteProviderRequest) Sign(peerID peer.ID, key crypto.PrivKey) error {
+	if p.IsSigned() {
+		return errors.New("already signed")
+	}
+
+	if key == nil {
+		return errors.New("no key provided")
+	}
+
+	sid, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if sid != peerID {
+		return errors.New("not the correct signing key")
+	}
+
+	err = p.setRawPayload()
+	if err != nil {
+		return err
+	}
+	sig, err := envelope.Sign(key, signingDomainWriteProviderRequest, signingTypeHintWriteProviderRequest, []byte(p.rawPayload))
+	if err != nil {
+		return err
+	}
+
+	sigStr, err := multibase.Encode(multibase.Base64, sig)
+	if err != nil {
+		return fmt.Errorf("multibase-encoding signature: %w", err)
+	}
+
+	p.Signature = sigStr
+	return nil
+}
+
+func (p *WriteProviderRequest) Verify() error {
+	if !p.IsSigned() {
+		return errors.New("not signed")
+	}
+
+	if p.Provider.ID == nil {
+		return errors.New("peer ID must be specified")
+	}
+
+	// note that we only generate and set the payload if it hasn't already been set
+	// to allow for passing through the payload untouched if it is already provided
+	if p.rawPayload == "" {
+		err := p.setRawPayload()
+		if err != nil {
+			return err
+		}
+	}
+
+	pk, err := p.Provider.ID.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("extracing public key from peer ID: %w", err)
+	}
+
+	_, sigBytes, err := multibase.Decode(p.Signature)
+	if err != nil {
+		return fmt.Errorf("multibase-decoding signature to verify: %w", err)
+	}
+
+	ok, err := envelope.VerifyWithLegacyFallback(pk, signingDomainWriteProviderRequest, signingTypeHintWriteProviderRequest, []byte(p.rawPayload), sigBytes)
+	if err != nil {
+		return fmt.Errorf("verifying hash with signature: %w", err)
+	}
+	if !ok {
+		return errors.New("signature failed to verify")
+	}
+
+	return nil
+}
+
+type WriteProviderResponse struct {
+	AdvisoryTTL Duration
+}